@@ -0,0 +1,237 @@
+// Package countingmultimap implements a multimap that tracks how many times
+// each value was put under a key, rather than deduping (setmultimap) or
+// keeping unbounded duplicates (slicemultimap).
+//
+// Put increments the count for a key-value pair, and Remove decrements it,
+// removing the pair entirely once its count reaches zero.
+//
+// Elements are unordered in the map.
+//
+// Structure is not thread safe.
+//
+package countingmultimap
+
+import (
+	"iter"
+
+	multimap "github.com/relengxing/go-multimap"
+)
+
+// MultiMap holds the elements and their multiplicities in go's native map.
+type MultiMap[K comparable, V comparable] struct {
+	m map[K]map[V]int
+}
+
+// New instantiates a new multimap.
+func New[K comparable, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{m: make(map[K]map[V]int)}
+}
+
+// Get searches the element in the multimap by key.
+// It returns its value or nil if key is not found in multimap.
+// Second return parameter is true if key was found, otherwise false.
+// Each distinct value is repeated according to its count.
+func (m *MultiMap[K, V]) Get(key K) (values []V, found bool) {
+	counts, found := m.m[key]
+	for value, count := range counts {
+		for i := 0; i < count; i++ {
+			values = append(values, value)
+		}
+	}
+	return
+}
+
+// Put stores a key-value pair in this multimap, incrementing the count for
+// (key, value) if it is already present.
+func (m *MultiMap[K, V]) Put(key K, value V) {
+	counts, found := m.m[key]
+	if !found {
+		counts = make(map[V]int)
+		m.m[key] = counts
+	}
+	counts[value]++
+}
+
+// PutAll stores a key-value pair in this multimap for each of the values, all using the same key key.
+func (m *MultiMap[K, V]) PutAll(key K, values []V) {
+	for _, value := range values {
+		m.Put(key, value)
+	}
+}
+
+// Count returns the number of times value is currently associated with key.
+func (m *MultiMap[K, V]) Count(key K, value V) int {
+	return m.m[key][value]
+}
+
+// DistinctValues returns the number of distinct values associated with key,
+// ignoring multiplicities.
+func (m *MultiMap[K, V]) DistinctValues(key K) int {
+	return len(m.m[key])
+}
+
+// Contains returns true if this multimap contains at least one key-value pair with the key key and the value value.
+func (m *MultiMap[K, V]) Contains(key K, value V) bool {
+	return m.m[key][value] > 0
+}
+
+// ContainsKey returns true if this multimap contains at least one key-value pair with the key key.
+func (m *MultiMap[K, V]) ContainsKey(key K) (found bool) {
+	_, found = m.m[key]
+	return
+}
+
+// ContainsValue returns true if this multimap contains at least one key-value pair with the value value.
+func (m *MultiMap[K, V]) ContainsValue(value V) bool {
+	for _, counts := range m.m {
+		if counts[value] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove removes a single occurrence of a key-value pair from this multimap, if such exists,
+// decrementing its count and deleting the entry entirely once the count reaches zero.
+func (m *MultiMap[K, V]) Remove(key K, value V) {
+	counts, found := m.m[key]
+	if !found {
+		return
+	}
+	if counts[value] <= 1 {
+		delete(counts, value)
+	} else {
+		counts[value]--
+	}
+	if len(counts) == 0 {
+		delete(m.m, key)
+	}
+}
+
+// RemoveAll removes all values associated with the key from the multimap.
+func (m *MultiMap[K, V]) RemoveAll(key K) {
+	delete(m.m, key)
+}
+
+// Empty returns true if multimap does not contain any key-value pairs.
+func (m *MultiMap[K, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+// Size returns the sum of all multiplicities, i.e. the total number of key-value pairs in the multimap.
+func (m *MultiMap[K, V]) Size() int {
+	size := 0
+	for _, counts := range m.m {
+		for _, count := range counts {
+			size += count
+		}
+	}
+	return size
+}
+
+// Keys returns a view collection containing the key from each key-value pair in this multimap.
+// This is done without collapsing duplicates.
+func (m *MultiMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Size())
+	for key, counts := range m.m {
+		for _, count := range counts {
+			for i := 0; i < count; i++ {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// KeySet returns all distinct keys contained in this multimap.
+func (m *MultiMap[K, V]) KeySet() []K {
+	keys := make([]K, 0, len(m.m))
+	for key := range m.m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns all values from each key-value pair contained in this multimap.
+// This is done without collapsing duplicates. (size of Values() = MultiMap.Size()).
+func (m *MultiMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Size())
+	for _, counts := range m.m {
+		for value, count := range counts {
+			for i := 0; i < count; i++ {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// Entries view collection of all key-value pairs contained in this multimap.
+// The return type is a slice of multimap.Entry instances. A pair put n times
+// appears n times in the result.
+func (m *MultiMap[K, V]) Entries() []multimap.Entry[K, V] {
+	entries := make([]multimap.Entry[K, V], 0, m.Size())
+	for key, counts := range m.m {
+		for value, count := range counts {
+			for i := 0; i < count; i++ {
+				entries = append(entries, multimap.Entry[K, V]{Key: key, Value: value})
+			}
+		}
+	}
+	return entries
+}
+
+// Range calls f sequentially for each key-value pair in the multimap, once
+// per occurrence of a pair put multiple times. If f returns false, Range
+// stops the iteration.
+func (m *MultiMap[K, V]) Range(f func(key K, value V) bool) {
+	for key, counts := range m.m {
+		for value, count := range counts {
+			for i := 0; i < count; i++ {
+				if !f(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// RangeKeys calls f sequentially for each key and all of its associated values,
+// expanded by multiplicity. If f returns false, RangeKeys stops the iteration.
+func (m *MultiMap[K, V]) RangeKeys(f func(key K, values []V) bool) {
+	for key, counts := range m.m {
+		values := make([]V, 0, len(counts))
+		for value, count := range counts {
+			for i := 0; i < count; i++ {
+				values = append(values, value)
+			}
+		}
+		if !f(key, values) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over all key-value pairs in the multimap, for use
+// with a range-over-func for loop: for k, v := range m.All() { ... }.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysSeq returns an iterator over the distinct keys in the multimap.
+func (m *MultiMap[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.m {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all elements from the map.
+func (m *MultiMap[K, V]) Clear() {
+	m.m = make(map[K]map[V]int)
+}
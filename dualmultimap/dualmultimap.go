@@ -0,0 +1,160 @@
+// Package dualmultimap implements a bidirectional multimap that can be
+// looked up efficiently from either side of a pair, e.g. users<->roles or
+// tags<->items.
+//
+// A DualMultiMap maintains two set-backed multimaps in lockstep, one keyed
+// on A and one keyed on B, so ByA and ByB are both O(1) lookups and every
+// mutation keeps both views consistent.
+//
+// A DualMultiMap cannot hold duplicate (a, b) pairs. Adding a pair that's
+// already in the multimap has no effect.
+//
+// Structure is not thread safe.
+//
+package dualmultimap
+
+import multimap "github.com/relengxing/go-multimap"
+
+var exists = struct{}{}
+
+// set represents a set object.
+type set[T comparable] map[T]struct{}
+
+// DualMultiMap holds the forward (A->B) and reverse (B->A) views in go's native maps.
+type DualMultiMap[A comparable, B comparable] struct {
+	forward map[A]set[B]
+	reverse map[B]set[A]
+}
+
+// New instantiates a new, empty dual multimap.
+func New[A comparable, B comparable]() *DualMultiMap[A, B] {
+	return &DualMultiMap[A, B]{
+		forward: make(map[A]set[B]),
+		reverse: make(map[B]set[A]),
+	}
+}
+
+// Put stores the (a, b) pair in both the forward and reverse views.
+func (m *DualMultiMap[A, B]) Put(a A, b B) {
+	if s, found := m.forward[a]; found {
+		s[b] = exists
+	} else {
+		m.forward[a] = set[B]{b: exists}
+	}
+	if s, found := m.reverse[b]; found {
+		s[a] = exists
+	} else {
+		m.reverse[b] = set[A]{a: exists}
+	}
+}
+
+// RemovePair removes the (a, b) pair from both views, if it exists.
+func (m *DualMultiMap[A, B]) RemovePair(a A, b B) {
+	if s, found := m.forward[a]; found {
+		delete(s, b)
+		if len(s) == 0 {
+			delete(m.forward, a)
+		}
+	}
+	if s, found := m.reverse[b]; found {
+		delete(s, a)
+		if len(s) == 0 {
+			delete(m.reverse, b)
+		}
+	}
+}
+
+// RemoveA removes a and every pair associated with it from both views.
+func (m *DualMultiMap[A, B]) RemoveA(a A) {
+	for b := range m.forward[a] {
+		if s, found := m.reverse[b]; found {
+			delete(s, a)
+			if len(s) == 0 {
+				delete(m.reverse, b)
+			}
+		}
+	}
+	delete(m.forward, a)
+}
+
+// RemoveB removes b and every pair associated with it from both views.
+func (m *DualMultiMap[A, B]) RemoveB(b B) {
+	for a := range m.reverse[b] {
+		if s, found := m.forward[a]; found {
+			delete(s, b)
+			if len(s) == 0 {
+				delete(m.forward, a)
+			}
+		}
+	}
+	delete(m.reverse, b)
+}
+
+// ByA returns all values associated with a.
+func (m *DualMultiMap[A, B]) ByA(a A) []B {
+	s := m.forward[a]
+	values := make([]B, 0, len(s))
+	for b := range s {
+		values = append(values, b)
+	}
+	return values
+}
+
+// ByB returns all values associated with b.
+func (m *DualMultiMap[A, B]) ByB(b B) []A {
+	s := m.reverse[b]
+	values := make([]A, 0, len(s))
+	for a := range s {
+		values = append(values, a)
+	}
+	return values
+}
+
+// ContainsPair returns true if the (a, b) pair is present in the multimap.
+func (m *DualMultiMap[A, B]) ContainsPair(a A, b B) bool {
+	_, ok := m.forward[a][b]
+	return ok
+}
+
+// Range calls f sequentially for each (a, b) pair in the multimap.
+// If f returns false, Range stops the iteration.
+func (m *DualMultiMap[A, B]) Range(f func(a A, b B) bool) {
+	for a, s := range m.forward {
+		for b := range s {
+			if !f(a, b) {
+				return
+			}
+		}
+	}
+}
+
+// Entries returns a view collection of every (a, b) pair contained in this multimap.
+func (m *DualMultiMap[A, B]) Entries() []multimap.Entry[A, B] {
+	entries := make([]multimap.Entry[A, B], 0, m.Size())
+	for a, s := range m.forward {
+		for b := range s {
+			entries = append(entries, multimap.Entry[A, B]{Key: a, Value: b})
+		}
+	}
+	return entries
+}
+
+// Empty returns true if the multimap does not contain any pairs.
+func (m *DualMultiMap[A, B]) Empty() bool {
+	return m.Size() == 0
+}
+
+// Size returns the number of (a, b) pairs in the multimap.
+func (m *DualMultiMap[A, B]) Size() int {
+	size := 0
+	for _, s := range m.forward {
+		size += len(s)
+	}
+	return size
+}
+
+// Clear removes all pairs from the multimap.
+func (m *DualMultiMap[A, B]) Clear() {
+	m.forward = make(map[A]set[B])
+	m.reverse = make(map[B]set[A])
+}
@@ -43,6 +43,13 @@ type MultiMap[K comparable, V any] interface {
 	KeySet() []K
 	Values() []V
 
+	// Range calls f sequentially for each key-value pair in the multimap.
+	// If f returns false, Range stops the iteration.
+	Range(f func(key K, value V) bool)
+	// RangeKeys calls f sequentially for each key and its associated values.
+	// If f returns false, RangeKeys stops the iteration.
+	RangeKeys(f func(key K, values []V) bool)
+
 	Clear()
 	Empty() bool
 	Size() int
@@ -9,7 +9,11 @@
 //
 package setmultimap
 
-import multimap "github.com/relengxing/go-multimap"
+import (
+	"iter"
+
+	multimap "github.com/relengxing/go-multimap"
+)
 
 var exists = struct{}{}
 
@@ -59,6 +63,15 @@ func (m *MultiMap[K, V]) PutAll(key K, values []V) {
 	}
 }
 
+// PutMultiMap stores every key-value pair of other in this multimap, merging other in place.
+func (m *MultiMap[K, V]) PutMultiMap(other *MultiMap[K, V]) {
+	for key, set := range other.m {
+		for value := range set {
+			m.Put(key, value)
+		}
+	}
+}
+
 // Contains returns true if this multimap contains at least one key-value pair with the key key and the value value.
 func (m *MultiMap[K, V]) Contains(key K, value V) bool {
 	set, found := m.m[key]
@@ -171,7 +184,117 @@ func (m *MultiMap[K, V]) Entries() []multimap.Entry[K, V] {
 	return entries
 }
 
+// Range calls f sequentially for each key-value pair in the multimap.
+// If f returns false, Range stops the iteration.
+func (m *MultiMap[K, V]) Range(f func(key K, value V) bool) {
+	for key, set := range m.m {
+		for value := range set {
+			if !f(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// RangeKeys calls f sequentially for each key and all of its associated values.
+// If f returns false, RangeKeys stops the iteration.
+func (m *MultiMap[K, V]) RangeKeys(f func(key K, values []V) bool) {
+	for key, set := range m.m {
+		values := make([]V, 0, len(set))
+		for value := range set {
+			values = append(values, value)
+		}
+		if !f(key, values) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over all key-value pairs in the multimap, for use
+// with a range-over-func for loop: for k, v := range m.All() { ... }.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysSeq returns an iterator over the distinct keys in the multimap.
+func (m *MultiMap[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.m {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
 // Clear removes all elements from the map.
 func (m *MultiMap[K, V]) Clear() {
 	m.m = make(map[K]Set[V])
 }
+
+// Inverse returns a new multimap where every (key, value) entry of m becomes
+// a (value, key) entry. This is useful for building reverse indexes without
+// manually iterating through Entries().
+func Inverse[K comparable, V comparable](m *MultiMap[K, V]) *MultiMap[V, K] {
+	inverse := New[V, K]()
+	for key, set := range m.m {
+		for value := range set {
+			inverse.Put(value, key)
+		}
+	}
+	return inverse
+}
+
+// Union returns a new multimap containing every (key, value) pair present in
+// either a or b.
+func Union[K comparable, V comparable](a, b *MultiMap[K, V]) *MultiMap[K, V] {
+	union := New[K, V]()
+	union.PutMultiMap(a)
+	union.PutMultiMap(b)
+	return union
+}
+
+// Intersection returns a new multimap containing only the (key, value) pairs
+// present in both a and b.
+func Intersection[K comparable, V comparable](a, b *MultiMap[K, V]) *MultiMap[K, V] {
+	intersection := New[K, V]()
+	for key, set := range a.m {
+		for value := range set {
+			if b.Contains(key, value) {
+				intersection.Put(key, value)
+			}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new multimap containing the (key, value) pairs present
+// in a but not in b.
+func Difference[K comparable, V comparable](a, b *MultiMap[K, V]) *MultiMap[K, V] {
+	difference := New[K, V]()
+	for key, set := range a.m {
+		for value := range set {
+			if !b.Contains(key, value) {
+				difference.Put(key, value)
+			}
+		}
+	}
+	return difference
+}
+
+// Equal returns true if a and b contain exactly the same (key, value) pairs.
+func Equal[K comparable, V comparable](a, b *MultiMap[K, V]) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	for key, set := range a.m {
+		for value := range set {
+			if !b.Contains(key, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,308 @@
+// Package syncmultimap implements a multimap safe for concurrent use by
+// multiple goroutines without additional locking or coordination.
+//
+// Like setmultimap, a syncmultimap cannot hold duplicate key-value pairs.
+// Adding a key-value pair that's already in the multimap has no effect.
+//
+// Elements are unordered in the map.
+//
+// Structure is safe for concurrent use, guarded internally by a sync.RWMutex.
+//
+package syncmultimap
+
+import (
+	"iter"
+	"sync"
+
+	multimap "github.com/relengxing/go-multimap"
+)
+
+var exists = struct{}{}
+
+// set represents a set object.
+type set[V comparable] map[V]struct{}
+
+// MultiMap holds the elements in go's native map, guarded by a sync.RWMutex.
+type MultiMap[K comparable, V comparable] struct {
+	mu sync.RWMutex
+	m  map[K]set[V]
+}
+
+// New instantiates a new, concurrent-safe multimap.
+func New[K comparable, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{m: make(map[K]set[V])}
+}
+
+// Get searches the element in the multimap by key.
+// It returns its value or nil if key is not found in multimap.
+// Second return parameter is true if key was found, otherwise false.
+func (m *MultiMap[K, V]) Get(key K) (values []V, found bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, found := m.m[key]
+	values = make([]V, len(s))
+	count := 0
+	for value := range s {
+		values[count] = value
+		count++
+	}
+	return
+}
+
+// Put stores a key-value pair in this multimap.
+func (m *MultiMap[K, V]) Put(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.putLocked(key, value)
+}
+
+func (m *MultiMap[K, V]) putLocked(key K, value V) {
+	s, found := m.m[key]
+	if found {
+		s[value] = exists
+	} else {
+		s = make(set[V])
+		s[value] = exists
+		m.m[key] = s
+	}
+}
+
+// PutAll stores a key-value pair in this multimap for each of the values, all using the same key key.
+func (m *MultiMap[K, V]) PutAll(key K, values []V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, value := range values {
+		m.putLocked(key, value)
+	}
+}
+
+// PutIfAbsent stores the key-value pair only if it is not already present.
+// It returns true if the pair was added, false if it was already present.
+func (m *MultiMap[K, V]) PutIfAbsent(key K, value V) (added bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, found := m.m[key]; found {
+		if _, ok := s[value]; ok {
+			return false
+		}
+	}
+	m.putLocked(key, value)
+	return true
+}
+
+// CompareAndRemove removes the key-value pair only if it is currently present,
+// reporting whether the pair was removed.
+func (m *MultiMap[K, V]) CompareAndRemove(key K, value V) (removed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, found := m.m[key]
+	if !found {
+		return false
+	}
+	if _, ok := s[value]; !ok {
+		return false
+	}
+	delete(s, value)
+	if len(s) == 0 {
+		delete(m.m, key)
+	}
+	return true
+}
+
+// GetOrPut returns the existing values for key if any are present.
+// Otherwise, it stores value under key and returns a single-element slice
+// containing it. The loaded result reports whether the values already existed.
+func (m *MultiMap[K, V]) GetOrPut(key K, value V) (values []V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, found := m.m[key]; found {
+		values = make([]V, 0, len(s))
+		for v := range s {
+			values = append(values, v)
+		}
+		return values, true
+	}
+	m.putLocked(key, value)
+	return []V{value}, false
+}
+
+// Range calls f sequentially for each key-value pair present in the multimap
+// at the moment Range is called. If f returns false, Range stops the
+// iteration. No copy is taken: the read lock is held for the whole iteration,
+// including every call to f, so f sees a consistent view of the multimap.
+func (m *MultiMap[K, V]) Range(f func(key K, value V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for key, s := range m.m {
+		for value := range s {
+			if !f(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// RangeKeys calls f sequentially for each key and all of its associated values,
+// holding the read lock for the whole iteration, including every call to f.
+// If f returns false, RangeKeys stops the iteration.
+func (m *MultiMap[K, V]) RangeKeys(f func(key K, values []V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for key, s := range m.m {
+		values := make([]V, 0, len(s))
+		for value := range s {
+			values = append(values, value)
+		}
+		if !f(key, values) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over all key-value pairs in the multimap, for use
+// with a range-over-func for loop: for k, v := range m.All() { ... }.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysSeq returns an iterator over the distinct keys in the multimap.
+func (m *MultiMap[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		for key := range m.m {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Contains returns true if this multimap contains at least one key-value pair with the key key and the value value.
+func (m *MultiMap[K, V]) Contains(key K, value V) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, found := m.m[key]
+	if _, ok := s[value]; ok {
+		return found
+	}
+	return false
+}
+
+// ContainsKey returns true if this multimap contains at least one key-value pair with the key key.
+func (m *MultiMap[K, V]) ContainsKey(key K) (found bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, found = m.m[key]
+	return
+}
+
+// ContainsValue returns true if this multimap contains at least one key-value pair with the value value.
+func (m *MultiMap[K, V]) ContainsValue(value V) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, s := range m.m {
+		if _, ok := s[value]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove removes a single key-value pair from this multimap, if such exists.
+func (m *MultiMap[K, V]) Remove(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, found := m.m[key]
+	if found {
+		delete(s, value)
+	}
+	if len(m.m[key]) == 0 {
+		delete(m.m, key)
+	}
+}
+
+// RemoveAll removes all values associated with the key from the multimap.
+func (m *MultiMap[K, V]) RemoveAll(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.m, key)
+}
+
+// Empty returns true if multimap does not contain any key-value pairs.
+func (m *MultiMap[K, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+// Size returns number of key-value pairs in the multimap.
+func (m *MultiMap[K, V]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	size := 0
+	for _, s := range m.m {
+		size += len(s)
+	}
+	return size
+}
+
+// Keys returns a view collection containing the key from each key-value pair in this multimap.
+// This is done without collapsing duplicates.
+func (m *MultiMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := make([]K, 0, len(m.m))
+	for key, s := range m.m {
+		for range s {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// KeySet returns all distinct keys contained in this multimap.
+func (m *MultiMap[K, V]) KeySet() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := make([]K, 0, len(m.m))
+	for key := range m.m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns all values from each key-value pair contained in this multimap.
+// This is done without collapsing duplicates. (size of Values() = MultiMap.Size()).
+func (m *MultiMap[K, V]) Values() []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	values := make([]V, 0, len(m.m))
+	for _, s := range m.m {
+		for value := range s {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Entries view collection of all key-value pairs contained in this multimap.
+// The return type is a slice of multimap.Entry instances.
+func (m *MultiMap[K, V]) Entries() []multimap.Entry[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entries := make([]multimap.Entry[K, V], 0, len(m.m))
+	for key, s := range m.m {
+		for value := range s {
+			entries = append(entries, multimap.Entry[K, V]{Key: key, Value: value})
+		}
+	}
+	return entries
+}
+
+// Clear removes all elements from the map.
+func (m *MultiMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m = make(map[K]set[V])
+}